@@ -0,0 +1,180 @@
+package errors
+
+import "encoding/json"
+
+// Category groups related Codes so that metrics, logging, and API responses
+// can filter or aggregate without hard-coding individual codes.
+type Category uint32
+
+const (
+	CategorySystem Category = iota
+	CategoryVM
+	CategoryPermission
+	CategoryGovernance
+	CategoryAccount
+	CategoryEvent
+)
+
+func (c Category) String() string {
+	switch c {
+	case CategoryVM:
+		return "VM"
+	case CategoryPermission:
+		return "Permission"
+	case CategoryGovernance:
+		return "Governance"
+	case CategoryAccount:
+		return "Account"
+	case CategoryEvent:
+		return "Event"
+	default:
+		return "System"
+	}
+}
+
+// Severity indicates how a Code should be treated by a caller: whether it
+// aborts the process, is expected to revert a transaction, or is merely
+// advisory.
+type Severity uint32
+
+const (
+	SeverityFatal Severity = iota
+	SeverityRevertable
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityRevertable:
+		return "Revertable"
+	case SeverityWarning:
+		return "Warning"
+	default:
+		return "Fatal"
+	}
+}
+
+// Category returns the Category that c belongs to.
+func (c Code) Category() Category {
+	switch c {
+	case ErrorCodeInvalidJumpDest, ErrorCodeMemoryOutOfBounds, ErrorCodeCodeOutOfBounds,
+		ErrorCodeInputOutOfBounds, ErrorCodeReturnDataOutOfBounds, ErrorCodeCallStackOverflow,
+		ErrorCodeCallStackUnderflow, ErrorCodeDataStackOverflow, ErrorCodeDataStackUnderflow,
+		ErrorCodeInvalidContract, ErrorCodeNativeContractCodeCopy, ErrorCodeExecutionAborted,
+		ErrorCodeExecutionReverted, ErrorCodeNativeFunction, ErrorCodeIllegalWrite,
+		ErrorCodeStaticCallStateChange, ErrorCodeIntegerOverflow, ErrorCodeInsufficientGas:
+		return CategoryVM
+	case ErrorCodePermissionDenied, ErrorCodeNoInputPermission, ErrorCodeReservedAddress:
+		return CategoryPermission
+	case ErrorCodeInvalidProposal, ErrorCodeExpiredProposal, ErrorCodeProposalExecuted, ErrorCodeAlreadyVoted:
+		return CategoryGovernance
+	case ErrorCodeUnknownAddress, ErrorCodeInvalidAddress, ErrorCodeDuplicateAddress,
+		ErrorCodeInsufficientBalance, ErrorCodeInsufficientFunds, ErrorCodeOverpayment,
+		ErrorCodeZeroPayment, ErrorCodeInvalidSequence:
+		return CategoryAccount
+	case ErrorCodeEventPublish, ErrorCodeEventMapping, ErrorCodeInvalidString:
+		return CategoryEvent
+	default:
+		return CategorySystem
+	}
+}
+
+// Severity returns the Severity that c should be treated with.
+func (c Code) Severity() Severity {
+	switch c {
+	case ErrorCodeExecutionReverted, ErrorCodeInsufficientBalance, ErrorCodeInsufficientFunds,
+		ErrorCodeInsufficientGas, ErrorCodeOverpayment, ErrorCodeZeroPayment, ErrorCodeInvalidSequence,
+		ErrorCodeInvalidProposal, ErrorCodeExpiredProposal, ErrorCodeProposalExecuted, ErrorCodeAlreadyVoted,
+		ErrorCodePermissionDenied, ErrorCodeNoInputPermission, ErrorCodeStaticCallStateChange:
+		return SeverityRevertable
+	case ErrorCodeEventPublish, ErrorCodeEventMapping, ErrorCodeInvalidString:
+		return SeverityWarning
+	default:
+		return SeverityFatal
+	}
+}
+
+// annotation is a single key/value context pair attached to an Exception via
+// With.
+type annotation struct {
+	Key   string
+	Value interface{}
+}
+
+// With attaches a key/value context pair to e and returns e for chaining. A
+// later call with a key already present overwrites the earlier value.
+func (e *Exception) With(key string, value interface{}) *Exception {
+	if e == nil {
+		return nil
+	}
+	e.annotations = append(e.annotations, annotation{Key: key, Value: value})
+	return e
+}
+
+// Annotations returns the key/value context pairs attached to e via With, as
+// a map keyed by Key; it does not preserve attachment order, and if With was
+// called more than once with the same key only the last value is present.
+func (e *Exception) Annotations() map[string]interface{} {
+	if e == nil || len(e.annotations) == 0 {
+		return nil
+	}
+	annotations := make(map[string]interface{}, len(e.annotations))
+	for _, a := range e.annotations {
+		annotations[a.Key] = a.Value
+	}
+	return annotations
+}
+
+// MarshalJSON emits e as {code, category, severity, message, annotations,
+// cause} so RPC clients and block explorers can render structured failures.
+func (e *Exception) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(struct {
+		Code        Code                   `json:"code"`
+		Category    string                 `json:"category"`
+		Severity    string                 `json:"severity"`
+		Message     string                 `json:"message"`
+		Annotations map[string]interface{} `json:"annotations,omitempty"`
+		Cause       string                 `json:"cause,omitempty"`
+	}{
+		Code:        e.Code,
+		Category:    e.Code.Category().String(),
+		Severity:    e.Code.Severity().String(),
+		Message:     e.Exception,
+		Annotations: e.Annotations(),
+		Cause:       causeMessage(e.cause),
+	})
+}
+
+// IsPermissionError returns true if err is a CodedError whose Code belongs
+// to CategoryPermission.
+func IsPermissionError(err error) bool {
+	return hasCategory(err, CategoryPermission)
+}
+
+// IsGasError returns true if err is a CodedError caused by running out of
+// gas.
+func IsGasError(err error) bool {
+	ex := AsException(err)
+	return ex != nil && ex.Code == ErrorCodeInsufficientGas
+}
+
+// IsGovernanceError returns true if err is a CodedError whose Code belongs
+// to CategoryGovernance.
+func IsGovernanceError(err error) bool {
+	return hasCategory(err, CategoryGovernance)
+}
+
+func hasCategory(err error, category Category) bool {
+	ex := AsException(err)
+	return ex != nil && ex.Code.Category() == category
+}
+
+func causeMessage(cause error) string {
+	if cause == nil {
+		return ""
+	}
+	return cause.Error()
+}
@@ -0,0 +1,158 @@
+// Package errgrpc bridges Burrow's CodedError hierarchy to gRPC status
+// errors so RPC handlers can surface structured failures to clients instead
+// of opaque strings.
+package errgrpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/hyperledger/burrow/execution/errors"
+)
+
+// typeURL identifies the wireException payload we attach to a status as a
+// Details entry. It is not a registered protobuf message, so FromGRPC only
+// trusts details whose TypeUrl matches this constant.
+const typeURL = "type.googleapis.com/burrow.errors.Exception"
+
+// wireException is the payload round-tripped through a status Details entry.
+// It is kept independent of Exception's own layout so that adding fields to
+// Exception does not silently break older clients decoding this blob.
+type wireException struct {
+	Code       uint32 `json:"code"`
+	Exception  string `json:"exception"`
+	RevertData []byte `json:"revert_data,omitempty"`
+}
+
+// ToGRPC converts err into a gRPC status error, mapping its Code to the
+// closest codes.Code and attaching the Exception's Code, full message and
+// RevertData as a status Details entry so FromGRPC can restore them. The
+// message of any wrapped cause is carried only as part of that one string:
+// annotations and the cause chain itself are not transmitted. Returns nil if
+// err is nil.
+func ToGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	ex := errors.AsException(err)
+	if ex == nil {
+		// err is non-nil but AsException collapsed it via NewException's
+		// unrelated "empty message means no exception" convention (e.g. a
+		// CodedError whose Error() is ""). Don't let that turn a real failure
+		// into status.FromError(nil)'s "success".
+		return status.New(codes.Unknown, err.Error()).Err()
+	}
+	st := status.New(codeToGRPC(ex.ErrorCode()), ex.Error())
+	detail, encErr := encodeException(ex)
+	if encErr != nil {
+		return st.Err()
+	}
+	proto := st.Proto()
+	proto.Details = append(proto.Details, detail)
+	return status.FromProto(proto).Err()
+}
+
+// FromGRPC recovers a CodedError from a gRPC status error. If err carries a
+// wireException detail attached by ToGRPC, the original Code and message are
+// restored exactly; otherwise the gRPC code is mapped back to the closest
+// Code. Returns nil if err is nil.
+func FromGRPC(err error) errors.CodedError {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return errors.AsException(err)
+	}
+	for _, d := range st.Proto().GetDetails() {
+		if ex, ok := decodeException(d); ok {
+			return ex
+		}
+	}
+	// Built directly so that a status with an empty message still yields a
+	// non-nil Exception carrying the mapped Code.
+	return &errors.Exception{Code: grpcToCode(st.Code()), Exception: st.Message()}
+}
+
+func encodeException(ex *errors.Exception) (*anypb.Any, error) {
+	data, err := json.Marshal(wireException{
+		Code:       ex.ErrorCode().Uint32(),
+		Exception:  ex.Error(),
+		RevertData: ex.RevertData,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &anypb.Any{TypeUrl: typeURL, Value: data}, nil
+}
+
+func decodeException(a *anypb.Any) (*errors.Exception, bool) {
+	if a.GetTypeUrl() != typeURL {
+		return nil, false
+	}
+	var w wireException
+	if err := json.Unmarshal(a.GetValue(), &w); err != nil {
+		return nil, false
+	}
+	// Built directly rather than via NewException: that constructor treats an
+	// empty message as "no exception" and returns nil, but a revert with no
+	// reason string (errors.NewRevert(data, "")) is a common, legitimate case.
+	return &errors.Exception{
+		Code:       errors.Code(w.Code),
+		Exception:  w.Exception,
+		RevertData: w.RevertData,
+	}, true
+}
+
+// codeToGRPC maps a Burrow Code to the closest gRPC code. Codes with no
+// obvious analogue fall through to codes.Unknown.
+func codeToGRPC(code errors.Code) codes.Code {
+	switch code {
+	case errors.ErrorCodePermissionDenied, errors.ErrorCodeNoInputPermission:
+		return codes.PermissionDenied
+	case errors.ErrorCodeInsufficientBalance, errors.ErrorCodeInsufficientFunds, errors.ErrorCodeInsufficientGas:
+		return codes.ResourceExhausted
+	case errors.ErrorCodeUnknownAddress:
+		return codes.NotFound
+	case errors.ErrorCodeInvalidAddress, errors.ErrorCodeInvalidSequence, errors.ErrorCodeInvalidString,
+		errors.ErrorCodeInvalidContract, errors.ErrorCodeInvalidProposal:
+		return codes.InvalidArgument
+	case errors.ErrorCodeExecutionReverted, errors.ErrorCodeExecutionAborted:
+		return codes.Aborted
+	case errors.ErrorCodeIllegalWrite, errors.ErrorCodeStaticCallStateChange:
+		return codes.FailedPrecondition
+	case errors.ErrorCodeIntegerOverflow, errors.ErrorCodeInvalidJumpDest, errors.ErrorCodeMemoryOutOfBounds,
+		errors.ErrorCodeCodeOutOfBounds, errors.ErrorCodeInputOutOfBounds, errors.ErrorCodeReturnDataOutOfBounds,
+		errors.ErrorCodeCallStackOverflow, errors.ErrorCodeCallStackUnderflow,
+		errors.ErrorCodeDataStackOverflow, errors.ErrorCodeDataStackUnderflow:
+		return codes.OutOfRange
+	default:
+		return codes.Unknown
+	}
+}
+
+// grpcToCode is the inverse of codeToGRPC, used when a status carries no
+// wireException detail to recover from exactly.
+func grpcToCode(code codes.Code) errors.Code {
+	switch code {
+	case codes.PermissionDenied:
+		return errors.ErrorCodePermissionDenied
+	case codes.ResourceExhausted:
+		return errors.ErrorCodeInsufficientFunds
+	case codes.NotFound:
+		return errors.ErrorCodeUnknownAddress
+	case codes.InvalidArgument:
+		return errors.ErrorCodeInvalidAddress
+	case codes.Aborted:
+		return errors.ErrorCodeExecutionReverted
+	case codes.FailedPrecondition:
+		return errors.ErrorCodeIllegalWrite
+	case codes.OutOfRange:
+		return errors.ErrorCodeIntegerOverflow
+	default:
+		return errors.ErrorCodeGeneric
+	}
+}
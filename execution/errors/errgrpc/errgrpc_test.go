@@ -0,0 +1,105 @@
+package errgrpc
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hyperledger/burrow/execution/errors"
+)
+
+func TestRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		ex   *errors.Exception
+	}{
+		{"generic", errors.NewException(errors.ErrorCodeInsufficientGas, "out of gas")},
+		{"permission denied", errors.NewException(errors.ErrorCodePermissionDenied, "no input permission")},
+		{"revert with reason", errors.NewRevert([]byte{0x01, 0x02}, "insufficient funds")},
+		{"revert with empty reason", errors.NewRevert([]byte{0x01, 0x02, 0x03}, "")},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			back := FromGRPC(ToGRPC(c.ex))
+			if back == nil {
+				t.Fatalf("FromGRPC(ToGRPC(%v)) = nil", c.ex)
+			}
+			if back.ErrorCode() != c.ex.ErrorCode() {
+				t.Errorf("Code = %v, want %v", back.ErrorCode(), c.ex.ErrorCode())
+			}
+			if back.Error() != c.ex.Error() {
+				t.Errorf("Error() = %q, want %q", back.Error(), c.ex.Error())
+			}
+			ex, ok := back.(*errors.Exception)
+			if !ok {
+				t.Fatalf("FromGRPC returned %T, want *errors.Exception", back)
+			}
+			if string(ex.RevertData) != string(c.ex.RevertData) {
+				t.Errorf("RevertData = %v, want %v", ex.RevertData, c.ex.RevertData)
+			}
+		})
+	}
+}
+
+func TestToGRPC_Nil(t *testing.T) {
+	if err := ToGRPC(nil); err != nil {
+		t.Errorf("ToGRPC(nil) = %v, want nil", err)
+	}
+}
+
+// emptyMessageError is a CodedError whose Error() is "", which errors.AsException
+// (like any error with an empty message) collapses from non-nil to nil.
+type emptyMessageError struct{}
+
+func (emptyMessageError) Error() string          { return "" }
+func (emptyMessageError) ErrorCode() errors.Code { return errors.ErrorCodeGeneric }
+
+func TestToGRPC_NonNilErrorWithEmptyMessage(t *testing.T) {
+	err := ToGRPC(emptyMessageError{})
+	if err == nil {
+		t.Fatal("ToGRPC(emptyMessageError{}) = nil, want a non-nil status error for a non-nil input")
+	}
+	if status.Code(err) != codes.Unknown {
+		t.Errorf("status.Code() = %v, want %v", status.Code(err), codes.Unknown)
+	}
+}
+
+func TestToGRPC_CodeMapping(t *testing.T) {
+	cases := []struct {
+		code errors.Code
+		want codes.Code
+	}{
+		{errors.ErrorCodePermissionDenied, codes.PermissionDenied},
+		{errors.ErrorCodeInsufficientGas, codes.ResourceExhausted},
+		{errors.ErrorCodeUnknownAddress, codes.NotFound},
+		{errors.ErrorCodeInvalidAddress, codes.InvalidArgument},
+		{errors.ErrorCodeExecutionReverted, codes.Aborted},
+		{errors.ErrorCodeIntegerOverflow, codes.OutOfRange},
+		{errors.ErrorCodeStaticCallStateChange, codes.FailedPrecondition},
+		{errors.ErrorCodeGeneric, codes.Unknown},
+	}
+	for _, c := range cases {
+		ex := errors.NewException(c.code, "boom")
+		st, ok := status.FromError(ToGRPC(ex))
+		if !ok {
+			t.Fatalf("ToGRPC(%v) did not produce a status error", c.code)
+		}
+		if st.Code() != c.want {
+			t.Errorf("codeToGRPC(%v) = %v, want %v", c.code, st.Code(), c.want)
+		}
+	}
+}
+
+// FromGRPC must still recover a sensible Code from a status with no
+// wireException detail attached, e.g. one raised by a plain gRPC server.
+func TestFromGRPC_NoDetailFallsBackToCodeMapping(t *testing.T) {
+	err := status.Error(codes.PermissionDenied, "nope")
+	back := FromGRPC(err)
+	if back == nil {
+		t.Fatal("FromGRPC returned nil")
+	}
+	if back.ErrorCode() != errors.ErrorCodePermissionDenied {
+		t.Errorf("ErrorCode() = %v, want %v", back.ErrorCode(), errors.ErrorCodePermissionDenied)
+	}
+}
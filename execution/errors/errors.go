@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type CodedError interface {
 	error
@@ -55,6 +58,7 @@ const (
 	ErrorCodeProposalExecuted
 	ErrorCodeNoInputPermission
 	ErrorCodeAlreadyVoted
+	ErrorCodeStaticCallStateChange
 )
 
 func (c Code) ErrorCode() Code {
@@ -143,11 +147,26 @@ func (c Code) String() string {
 		return "Account has no input permission"
 	case ErrorCodeAlreadyVoted:
 		return "Vote already registered for this address"
+	case ErrorCodeStaticCallStateChange:
+		return "Callee attempted to change state from within a STATICCALL"
 	default:
 		return "Unknown error"
 	}
 }
 
+// Exception is the concrete CodedError carried through the execution and RPC
+// layers. annotations accumulate context added via With, in the order they
+// were attached.
+type Exception struct {
+	Code        Code
+	Exception   string
+	annotations []annotation
+	cause       error
+	// RevertData is the raw return data from a reverted call. It is only
+	// populated when Code == ErrorCodeExecutionReverted.
+	RevertData []byte
+}
+
 func NewException(errorCode Code, exception string) *Exception {
 	if exception == "" {
 		return nil
@@ -173,9 +192,62 @@ func AsException(err error) *Exception {
 	}
 }
 
+// Wrap annotates err with message while preserving err in the chain, so that
+// errors.Is/errors.As (and this package's own Is/As) can still see through to
+// the original cause.
 func Wrap(err error, message string) *Exception {
 	ex := AsException(err)
-	return NewException(ex.ErrorCode(), message+": "+ex.Error())
+	if ex == nil {
+		return NewException(ErrorCodeGeneric, message)
+	}
+	return &Exception{
+		Code:      ex.ErrorCode(),
+		Exception: message,
+		cause:     err,
+	}
+}
+
+// Join aggregates errs into a single CodedError whose Code is that of the
+// highest-severity input, complementing FirstOnly's first-wins Sink. Inputs
+// that are nil or not convertible to an Exception are skipped. Returns nil
+// if no input yields an Exception.
+func Join(errs ...error) CodedError {
+	var exceptions []*Exception
+	for _, err := range errs {
+		if ex := AsException(err); ex != nil {
+			exceptions = append(exceptions, ex)
+		}
+	}
+	if len(exceptions) == 0 {
+		return nil
+	}
+	highest := exceptions[0]
+	messages := make([]string, len(exceptions))
+	for i, ex := range exceptions {
+		if severityRank(ex.Code.Severity()) > severityRank(highest.Code.Severity()) {
+			highest = ex
+		}
+		messages[i] = ex.Error()
+	}
+	// highest's message is already included in messages, so it is not also
+	// set as cause: Error() would otherwise append it a second time.
+	return &Exception{
+		Code:      highest.Code,
+		Exception: strings.Join(messages, "; "),
+	}
+}
+
+// severityRank orders Severity from least (0) to most (2) severe so Join can
+// pick the worst of a set of errors.
+func severityRank(s Severity) int {
+	switch s {
+	case SeverityWarning:
+		return 0
+	case SeverityRevertable:
+		return 1
+	default:
+		return 2
+	}
 }
 
 func Errorf(format string, a ...interface{}) *Exception {
@@ -206,9 +278,55 @@ func (e *Exception) Error() string {
 	if e == nil {
 		return ""
 	}
+	if e.cause != nil {
+		return e.Exception + ": " + e.cause.Error()
+	}
 	return e.Exception
 }
 
+// Unwrap returns the error e wraps, if any, so that e participates in
+// errors.Is/errors.As chains rooted anywhere in the standard library.
+func (e *Exception) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.cause
+}
+
+// Is reports whether target is a Code equal to e.Code, or an *Exception with
+// the same Code. It lets callers write errors.Is(err, errors.ErrorCodeInsufficientGas)
+// without caring whether err is a bare Code, an *Exception, or wraps one.
+func (e *Exception) Is(target error) bool {
+	if e == nil {
+		return target == nil
+	}
+	switch t := target.(type) {
+	case Code:
+		return e.Code == t
+	case *Exception:
+		return t != nil && e.Code == t.Code
+	default:
+		return false
+	}
+}
+
+// As supports errors.As(err, target) for target types *CodedError and
+// *Exception.
+func (e *Exception) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *CodedError:
+		*t = e
+		return true
+	case **Exception:
+		*t = e
+		return true
+	default:
+		return false
+	}
+}
+
+// Equal is retained for backwards compatibility; prefer errors.Is going
+// forward since it also sees through wrapped causes.
 func (e *Exception) Equal(ce CodedError) bool {
 	ex := AsException(ce)
 	if e == nil || ex == nil {
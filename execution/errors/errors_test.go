@@ -0,0 +1,109 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestExceptionIs(t *testing.T) {
+	ex := NewException(ErrorCodeInsufficientGas, "out of gas")
+	if !stderrors.Is(ex, ErrorCodeInsufficientGas) {
+		t.Error("expected errors.Is to match the bare Code")
+	}
+	if stderrors.Is(ex, ErrorCodePermissionDenied) {
+		t.Error("did not expect errors.Is to match an unrelated Code")
+	}
+	wrapped := Wrap(ex, "while executing")
+	if !stderrors.Is(wrapped, ErrorCodeInsufficientGas) {
+		t.Error("expected errors.Is to see through Wrap to the cause's Code")
+	}
+}
+
+func TestExceptionAs(t *testing.T) {
+	var ex *Exception
+	wrapped := Wrap(NewException(ErrorCodeInsufficientGas, "out of gas"), "while executing")
+	if !stderrors.As(wrapped, &ex) {
+		t.Fatal("expected errors.As to match *Exception")
+	}
+	if ex.Code != ErrorCodeInsufficientGas {
+		t.Errorf("Code = %v, want %v", ex.Code, ErrorCodeInsufficientGas)
+	}
+}
+
+func TestWrapPreservesCauseChain(t *testing.T) {
+	cause := NewException(ErrorCodeInsufficientGas, "out of gas")
+	wrapped := Wrap(cause, "while executing")
+	if wrapped.Unwrap() != error(cause) {
+		t.Error("expected Wrap to preserve the original error as its cause")
+	}
+	if want := "while executing: out of gas"; wrapped.Error() != want {
+		t.Errorf("Error() = %q, want %q", wrapped.Error(), want)
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	wrapped := Wrap(nil, "while executing")
+	if wrapped == nil {
+		t.Fatal("Wrap(nil, ...) returned nil")
+	}
+	if wrapped.ErrorCode() != ErrorCodeGeneric {
+		t.Errorf("Code = %v, want %v", wrapped.ErrorCode(), ErrorCodeGeneric)
+	}
+	if wrapped.Unwrap() != nil {
+		t.Error("expected Wrap(nil, ...) to have no cause")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if Join() != nil {
+		t.Error("Join() with no errors should return nil")
+	}
+	gas := NewException(ErrorCodeInsufficientGas, "out of gas")      // Revertable
+	overflow := NewException(ErrorCodeIntegerOverflow, "overflowed") // Fatal
+	joined := Join(gas, overflow)
+	if joined == nil {
+		t.Fatal("Join returned nil")
+	}
+	if joined.ErrorCode() != ErrorCodeIntegerOverflow {
+		t.Errorf("Code = %v, want the higher-severity %v", joined.ErrorCode(), ErrorCodeIntegerOverflow)
+	}
+	want := "out of gas; overflowed"
+	if joined.Error() != want {
+		t.Errorf("Error() = %q, want %q (no duplicated message)", joined.Error(), want)
+	}
+}
+
+func TestCategoryAndSeverity(t *testing.T) {
+	if ErrorCodeInsufficientGas.Category() != CategoryVM {
+		t.Errorf("Category() = %v, want %v", ErrorCodeInsufficientGas.Category(), CategoryVM)
+	}
+	if ErrorCodePermissionDenied.Category() != CategoryPermission {
+		t.Errorf("Category() = %v, want %v", ErrorCodePermissionDenied.Category(), CategoryPermission)
+	}
+	if ErrorCodeInsufficientGas.Severity() != SeverityRevertable {
+		t.Errorf("Severity() = %v, want %v", ErrorCodeInsufficientGas.Severity(), SeverityRevertable)
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	if !IsPermissionError(NewException(ErrorCodePermissionDenied, "denied")) {
+		t.Error("expected IsPermissionError to match ErrorCodePermissionDenied")
+	}
+	if !IsGasError(NewException(ErrorCodeInsufficientGas, "out of gas")) {
+		t.Error("expected IsGasError to match ErrorCodeInsufficientGas")
+	}
+	if !IsGovernanceError(NewException(ErrorCodeAlreadyVoted, "already voted")) {
+		t.Error("expected IsGovernanceError to match ErrorCodeAlreadyVoted")
+	}
+	if IsGovernanceError(NewException(ErrorCodeInsufficientGas, "out of gas")) {
+		t.Error("did not expect IsGovernanceError to match ErrorCodeInsufficientGas")
+	}
+}
+
+func TestWithAnnotations(t *testing.T) {
+	ex := NewException(ErrorCodeInsufficientGas, "out of gas").With("account", "0xABC")
+	annotations := ex.Annotations()
+	if annotations["account"] != "0xABC" {
+		t.Errorf("Annotations()[\"account\"] = %v, want 0xABC", annotations["account"])
+	}
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+)
+
+// Standard Solidity revert selectors, as encoded in the return data of a
+// reverted call: Error(string) for require/revert with a reason string, and
+// Panic(uint256) for compiler-inserted checks (assert, overflow, etc).
+var (
+	errorStringSelector  = []byte{0x08, 0xc3, 0x79, 0xa0}
+	panicUint256Selector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// NewRevert builds an ErrorCodeExecutionReverted Exception carrying the raw
+// revert data alongside a human-readable reason, matching what go-ethereum
+// exposes via eth_call.
+func NewRevert(data []byte, reason string) *Exception {
+	return &Exception{
+		Code:       ErrorCodeExecutionReverted,
+		Exception:  reason,
+		RevertData: data,
+	}
+}
+
+// RevertReason ABI-decodes e.RevertData as a standard Error(string) or
+// Panic(uint256) selector, returning the decoded reason and true if
+// recognised. Returns false if e is not a revert or RevertData is not one of
+// the standard selectors.
+func (e *Exception) RevertReason() (string, bool) {
+	if e == nil || e.Code != ErrorCodeExecutionReverted || len(e.RevertData) < 4 {
+		return "", false
+	}
+	selector, data := e.RevertData[:4], e.RevertData[4:]
+	switch {
+	case bytes.Equal(selector, errorStringSelector):
+		return decodeABIString(data)
+	case bytes.Equal(selector, panicUint256Selector):
+		if len(data) < 32 {
+			return "", false
+		}
+		return fmt.Sprintf("panic: 0x%x", new(big.Int).SetBytes(data[:32])), true
+	default:
+		return "", false
+	}
+}
+
+// decodeABIString decodes the ABI encoding of a single dynamic string
+// argument: a 32-byte offset (ignored, always 0x20 for a lone argument), a
+// 32-byte length, followed by the (padded) string bytes.
+func decodeABIString(data []byte) (string, bool) {
+	if len(data) < 64 {
+		return "", false
+	}
+	length := new(big.Int).SetBytes(data[32:64]).Uint64()
+	if uint64(len(data)) < 64+length {
+		return "", false
+	}
+	return string(data[64 : 64+length]), true
+}
@@ -0,0 +1,103 @@
+package errors
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// multiSink fans a pushed error out to every one of sinks.
+type multiSink struct {
+	sinks []Sink
+}
+
+// MultiSink composes several Sinks so that each pushed error reaches all of
+// them, e.g. MultiSink(FirstOnly(), CountingSink(reg), TracingSink(ctx)).
+func MultiSink(sinks ...Sink) Sink {
+	return &multiSink{sinks: sinks}
+}
+
+// PushError upgrades err to an *Exception once, up front, and threads that
+// same pointer to every sink. Without this, a sink like ContextSink that
+// annotates its argument via With would be annotating a throwaway Exception
+// allocated by its own AsException(err) call whenever err is not already an
+// *Exception (e.g. a bare Code), and later sinks in the chain would never see
+// the annotation.
+func (m *multiSink) PushError(err error) {
+	if ex := AsException(err); ex != nil {
+		err = ex
+	}
+	for _, sink := range m.sinks {
+		sink.PushError(err)
+	}
+}
+
+// countingSink tallies pushed errors per Code.
+type countingSink struct {
+	counts *prometheus.CounterVec
+}
+
+// CountingSink exposes per-Code counters of pushed errors via counts, which
+// should be registered with label "code".
+func CountingSink(counts *prometheus.CounterVec) Sink {
+	return &countingSink{counts: counts}
+}
+
+func (c *countingSink) PushError(err error) {
+	ex := AsException(err)
+	if ex == nil {
+		return
+	}
+	c.counts.WithLabelValues(ex.Code.String()).Inc()
+}
+
+// tracingSink records pushed errors as span events on ctx's span.
+type tracingSink struct {
+	ctx context.Context
+}
+
+// TracingSink records each pushed error as an OpenTelemetry span event on the
+// span found in ctx, with attributes error.code, error.category, and
+// error.message.
+func TracingSink(ctx context.Context) Sink {
+	return &tracingSink{ctx: ctx}
+}
+
+func (t *tracingSink) PushError(err error) {
+	ex := AsException(err)
+	if ex == nil {
+		return
+	}
+	trace.SpanFromContext(t.ctx).AddEvent("error", trace.WithAttributes(
+		attribute.String("error.code", ex.Code.String()),
+		attribute.String("error.category", ex.Code.Category().String()),
+		attribute.String("error.message", ex.Error()),
+	))
+}
+
+// contextSink correlates pushed errors with the request captured by ctx by
+// tagging them with its trace ID, if any, so logs and traces recorded
+// downstream from the same Sink chain can be tied back to the request.
+type contextSink struct {
+	ctx context.Context
+}
+
+// ContextSink associates pushed errors with the request-scoped ctx. It is
+// typically composed ahead of TracingSink/CountingSink in a MultiSink so that
+// the trace_id annotation it attaches is visible to them.
+func ContextSink(ctx context.Context) Sink {
+	return &contextSink{ctx: ctx}
+}
+
+func (c *contextSink) PushError(err error) {
+	ex := AsException(err)
+	if ex == nil {
+		return
+	}
+	spanContext := trace.SpanContextFromContext(c.ctx)
+	if spanContext.HasTraceID() {
+		ex.With("trace_id", spanContext.TraceID().String())
+	}
+}
@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"context"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// recordingSink remembers every error it is pushed, so tests can inspect
+// exactly what MultiSink threaded through to it.
+type recordingSink struct {
+	pushed []error
+}
+
+func (r *recordingSink) PushError(err error) {
+	r.pushed = append(r.pushed, err)
+}
+
+func TestMultiSinkFansOut(t *testing.T) {
+	a, b := &recordingSink{}, &recordingSink{}
+	ex := NewException(ErrorCodeInsufficientGas, "out of gas")
+	MultiSink(a, b).PushError(ex)
+	if len(a.pushed) != 1 || len(b.pushed) != 1 {
+		t.Fatalf("expected both sinks to receive one error, got %d and %d", len(a.pushed), len(b.pushed))
+	}
+}
+
+// TestMultiSinkThreadsUpgradedException is the regression test for annotations
+// added by one sink (e.g. ContextSink) being lost before later sinks in the
+// chain observe them: MultiSink must upgrade a bare Code to the same
+// *Exception once, up front, so every sink sees it.
+func TestMultiSinkThreadsUpgradedException(t *testing.T) {
+	recorder := &recordingSink{}
+	ctx := context.Background()
+	MultiSink(ContextSink(ctx), recorder).PushError(ErrorCodePermissionDenied)
+	if len(recorder.pushed) != 1 {
+		t.Fatalf("expected recorder to receive one error, got %d", len(recorder.pushed))
+	}
+	ex, ok := recorder.pushed[0].(*Exception)
+	if !ok {
+		t.Fatalf("expected recorder to observe an *Exception, got %T", recorder.pushed[0])
+	}
+	if ex.Code != ErrorCodePermissionDenied {
+		t.Errorf("Code = %v, want %v", ex.Code, ErrorCodePermissionDenied)
+	}
+}
+
+func TestCountingSink(t *testing.T) {
+	counts := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_errors_total"}, []string{"code"})
+	sink := CountingSink(counts)
+	sink.PushError(NewException(ErrorCodeInsufficientGas, "out of gas"))
+	sink.PushError(NewException(ErrorCodeInsufficientGas, "out of gas"))
+	got := testutilCounterValue(t, counts, ErrorCodeInsufficientGas.String())
+	if got != 2 {
+		t.Errorf("counter for %s = %v, want 2", ErrorCodeInsufficientGas, got)
+	}
+}
+
+func testutilCounterValue(t *testing.T, counts *prometheus.CounterVec, label string) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := counts.WithLabelValues(label).Write(&m); err != nil {
+		t.Fatalf("failed to read counter: %v", err)
+	}
+	return m.Counter.GetValue()
+}